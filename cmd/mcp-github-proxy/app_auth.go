@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/go-github/v56/github"
+	"golang.org/x/oauth2"
+)
+
+// appAuth mints and caches GitHub App installation tokens. It authenticates
+// to the Apps API with a short-lived RS256 JWT signed by the app's private
+// key (iss = app ID, 10-minute expiry) and exchanges that for an
+// installation access token, which it caches until ~1 minute before it
+// expires.
+type appAuth struct {
+	appID      int64
+	privateKey *rsa.PrivateKey
+	client     *github.Client // unauthenticated client used only to call the Apps API
+
+	mu     sync.Mutex
+	tokens map[int64]*cachedInstallationToken // installation ID -> cached token
+}
+
+// cachedInstallationToken is a minted installation access token along with
+// when it expires.
+type cachedInstallationToken struct {
+	token     string
+	expiresAt time.Time
+}
+
+// newAppAuth parses privateKeyPEM and builds an appAuth that authenticates
+// as appID, minting tokens via client.
+func newAppAuth(appID int64, privateKeyPEM []byte, client *github.Client) (*appAuth, error) {
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(privateKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("parsing GitHub App private key: %w", err)
+	}
+	return &appAuth{appID: appID, privateKey: key, client: client, tokens: make(map[int64]*cachedInstallationToken)}, nil
+}
+
+// appJWT signs a 10-minute RS256 JWT identifying the app, as GitHub requires
+// to call the Apps API (minting installation tokens, listing installations).
+func (a *appAuth) appJWT() (string, error) {
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		IssuedAt:  jwt.NewNumericDate(now.Add(-30 * time.Second)), // allow for clock drift
+		ExpiresAt: jwt.NewNumericDate(now.Add(10 * time.Minute)),
+		Issuer:    strconv.FormatInt(a.appID, 10),
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(a.privateKey)
+}
+
+// installationToken returns a cached installation token for installationID,
+// minting a fresh one when none is cached for that installation or the
+// cached one is within a minute of expiring. Tokens are cached per
+// installation, since a single server process may be asked to mint tokens
+// for several installations over its lifetime.
+func (a *appAuth) installationToken(ctx context.Context, installationID int64) (string, time.Time, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if cached, ok := a.tokens[installationID]; ok && time.Until(cached.expiresAt) > time.Minute {
+		return cached.token, cached.expiresAt, nil
+	}
+
+	jwtToken, err := a.appJWT()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	req, err := a.client.NewRequest("POST", fmt.Sprintf("app/installations/%d/access_tokens", installationID), nil)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+jwtToken)
+
+	var result github.InstallationToken
+	if _, err := a.client.Do(ctx, req, &result); err != nil {
+		return "", time.Time{}, fmt.Errorf("minting installation token: %w", err)
+	}
+
+	cached := &cachedInstallationToken{token: result.GetToken(), expiresAt: result.GetExpiresAt().Time}
+	a.tokens[installationID] = cached
+	return cached.token, cached.expiresAt, nil
+}
+
+// listInstallations lists the installations visible to the app, authenticating
+// as the app itself via its JWT rather than an installation token.
+func (a *appAuth) listInstallations(ctx context.Context, opts *github.ListOptions) ([]*github.Installation, *github.Response, error) {
+	jwtToken, err := a.appJWT()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := a.client.NewRequest("GET", "app/installations", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+jwtToken)
+	if opts != nil {
+		q := req.URL.Query()
+		if opts.Page > 0 {
+			q.Set("page", strconv.Itoa(opts.Page))
+		}
+		if opts.PerPage > 0 {
+			q.Set("per_page", strconv.Itoa(opts.PerPage))
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	var installations []*github.Installation
+	resp, err := a.client.Do(ctx, req, &installations)
+	return installations, resp, err
+}
+
+// installationTokenSource is an oauth2.TokenSource backed by an appAuth,
+// refreshing the cached installation token as needed.
+type installationTokenSource struct {
+	ctx            context.Context
+	auth           *appAuth
+	installationID int64
+}
+
+// Token implements oauth2.TokenSource.
+func (s *installationTokenSource) Token() (*oauth2.Token, error) {
+	token, expiresAt, err := s.auth.installationToken(s.ctx, s.installationID)
+	if err != nil {
+		return nil, err
+	}
+	return &oauth2.Token{AccessToken: token, TokenType: "token", Expiry: expiresAt}, nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// defaultCacheMaxEntries bounds the in-memory conditional-request cache so
+// an LLM looping over many distinct endpoints can't grow it unbounded.
+const defaultCacheMaxEntries = 500
+
+// cacheEntry is a single cached response eligible for conditional
+// re-validation: its body plus the validator headers GitHub returned
+// alongside it.
+type cacheEntry struct {
+	Body         []byte
+	ETag         string
+	LastModified string
+	StoredAt     time.Time
+	TTL          time.Duration
+}
+
+func (e *cacheEntry) expired() bool {
+	return e.TTL > 0 && time.Since(e.StoredAt) > e.TTL
+}
+
+// cacheStats reports the conditional-request cache's hit/miss counts and
+// footprint, as returned by the github_cache_stats tool.
+type cacheStats struct {
+	Hits        int64 `json:"hits"`
+	Misses      int64 `json:"misses"`
+	Entries     int   `json:"entries"`
+	BytesStored int64 `json:"bytes_stored"`
+}
+
+// responseCache is a bounded in-memory LRU cache of GET responses from
+// CallGitHubAPI, keyed by (method, URL, auth). It lets repeated calls to
+// the same endpoint send If-None-Match/If-Modified-Since and serve the
+// cached body on a 304, which GitHub does not count against the rate
+// limit budget.
+type responseCache struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // front = most recently used
+	bytes   int64
+	hits    int64
+	misses  int64
+}
+
+type cacheListEntry struct {
+	key   string
+	entry *cacheEntry
+}
+
+func newResponseCache(maxEntries int) *responseCache {
+	return &responseCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// cacheKey identifies a cached response by (method, URL, auth), so that
+// identical requests made with a different identity (token, or GitHub App
+// installation) don't share a cache entry.
+func cacheKey(method, url, auth string) string {
+	sum := sha256.Sum256([]byte(auth))
+	return method + " " + url + " " + hex.EncodeToString(sum[:8])
+}
+
+// get returns the cached entry for key, if any and not expired. It does
+// not itself count as a hit or miss: the caller only knows whether this
+// was a real cache hit once GitHub confirms the entry is still fresh with
+// a 304.
+func (c *responseCache) get(key string) (*cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	le := el.Value.(*cacheListEntry)
+	if le.entry.expired() {
+		c.removeLocked(el, le)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return le.entry, true
+}
+
+func (c *responseCache) put(key string, entry *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		le := el.Value.(*cacheListEntry)
+		c.bytes += int64(len(entry.Body) - len(le.entry.Body))
+		le.entry = entry
+		c.order.MoveToFront(el)
+		return
+	}
+
+	el := c.order.PushFront(&cacheListEntry{key: key, entry: entry})
+	c.entries[key] = el
+	c.bytes += int64(len(entry.Body))
+
+	for c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		if oldest == nil {
+			break
+		}
+		c.removeLocked(oldest, oldest.Value.(*cacheListEntry))
+	}
+}
+
+func (c *responseCache) removeLocked(el *list.Element, le *cacheListEntry) {
+	c.order.Remove(el)
+	delete(c.entries, le.key)
+	c.bytes -= int64(len(le.entry.Body))
+}
+
+func (c *responseCache) recordHit()  { c.mu.Lock(); c.hits++; c.mu.Unlock() }
+func (c *responseCache) recordMiss() { c.mu.Lock(); c.misses++; c.mu.Unlock() }
+
+func (c *responseCache) stats() cacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return cacheStats{
+		Hits:        c.hits,
+		Misses:      c.misses,
+		Entries:     c.order.Len(),
+		BytesStored: c.bytes,
+	}
+}
@@ -1,35 +1,69 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/google/go-github/v56/github"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/shuymn/mcp/internal/common"
+	"github.com/shuymn/mcp/internal/forgeclient"
 )
 
-// GitHubAPIParams defines parameters for GitHub API calls
+// GitHubAPIParams defines parameters for generic GitHub API calls
 type GitHubAPIParams struct {
-	Endpoint string            `json:"endpoint"`
-	Method   string            `json:"method,omitempty"`
-	Token    string            `json:"token,omitempty"`
-	Body     map[string]any    `json:"body,omitempty"`
-	Headers  map[string]string `json:"headers,omitempty"`
+	Endpoint       string            `json:"endpoint"`
+	Method         string            `json:"method,omitempty"`
+	Token          string            `json:"token,omitempty"`
+	Body           map[string]any    `json:"body,omitempty"`
+	Headers        map[string]string `json:"headers,omitempty"`
+	AllPages       bool              `json:"all_pages,omitempty"`
+	MaxResults     int               `json:"max_results,omitempty"`
+	AppID          int64             `json:"app_id,omitempty"`
+	InstallationID int64             `json:"installation_id,omitempty"`
+	Cache          bool              `json:"cache,omitempty"`
+	CacheTTL       string            `json:"cache_ttl,omitempty"`
+}
+
+// ListInstallationsParams defines parameters for listing GitHub App installations
+type ListInstallationsParams struct {
+	PerPage int `json:"per_page,omitempty"`
+	Page    int `json:"page,omitempty"`
 }
 
 // SearchReposParams defines parameters for searching repositories
 type SearchReposParams struct {
-	Query   string `json:"query"`
-	Sort    string `json:"sort,omitempty"`
-	Order   string `json:"order,omitempty"`
-	PerPage int    `json:"per_page,omitempty"`
-	Page    int    `json:"page,omitempty"`
+	Query      string `json:"query"`
+	Sort       string `json:"sort,omitempty"`
+	Order      string `json:"order,omitempty"`
+	PerPage    int    `json:"per_page,omitempty"`
+	Page       int    `json:"page,omitempty"`
+	AllPages   bool   `json:"all_pages,omitempty"`
+	MaxResults int    `json:"max_results,omitempty"`
+}
+
+// ListReposParams defines parameters for listing a user's or organization's repositories
+type ListReposParams struct {
+	Owner      string `json:"owner,omitempty"`
+	Type       string `json:"type,omitempty"`
+	Sort       string `json:"sort,omitempty"`
+	Direction  string `json:"direction,omitempty"`
+	PerPage    int    `json:"per_page,omitempty"`
+	Page       int    `json:"page,omitempty"`
+	AllPages   bool   `json:"all_pages,omitempty"`
+	MaxResults int    `json:"max_results,omitempty"`
 }
 
 // GetUserParams defines parameters for getting user information
@@ -37,172 +71,665 @@ type GetUserParams struct {
 	Username string `json:"username"`
 }
 
+// GetRepoParams defines parameters for fetching a single repository
+type GetRepoParams struct {
+	Owner string `json:"owner"`
+	Repo  string `json:"repo"`
+}
+
+// ListIssuesParams defines parameters for listing issues on a repository
+type ListIssuesParams struct {
+	Owner      string `json:"owner"`
+	Repo       string `json:"repo"`
+	State      string `json:"state,omitempty"`
+	Labels     string `json:"labels,omitempty"`
+	Sort       string `json:"sort,omitempty"`
+	Direction  string `json:"direction,omitempty"`
+	PerPage    int    `json:"per_page,omitempty"`
+	Page       int    `json:"page,omitempty"`
+	AllPages   bool   `json:"all_pages,omitempty"`
+	MaxResults int    `json:"max_results,omitempty"`
+}
+
+// ListPullRequestsParams defines parameters for listing pull requests on a repository
+type ListPullRequestsParams struct {
+	Owner     string `json:"owner"`
+	Repo      string `json:"repo"`
+	State     string `json:"state,omitempty"`
+	Sort      string `json:"sort,omitempty"`
+	Direction string `json:"direction,omitempty"`
+	PerPage   int    `json:"per_page,omitempty"`
+	Page      int    `json:"page,omitempty"`
+}
+
+// GetReleaseParams defines parameters for fetching a repository release
+type GetReleaseParams struct {
+	Owner string `json:"owner"`
+	Repo  string `json:"repo"`
+	Tag   string `json:"tag,omitempty"`
+}
+
+// ListWorkflowRunsParams defines parameters for listing GitHub Actions workflow runs
+type ListWorkflowRunsParams struct {
+	Owner      string `json:"owner"`
+	Repo       string `json:"repo"`
+	WorkflowID string `json:"workflow_id,omitempty"`
+	Branch     string `json:"branch,omitempty"`
+	Status     string `json:"status,omitempty"`
+	PerPage    int    `json:"per_page,omitempty"`
+	Page       int    `json:"page,omitempty"`
+}
+
 const (
-	defaultGitHubAPIBase = "https://api.github.com"
-	userAgent            = "MCP-GitHub-Proxy/1.0"
+	defaultGitHubAPIBase    = "https://api.github.com"
+	userAgent               = "MCP-GitHub-Proxy/1.0"
+	defaultRateLimitMaxWait = 2 * time.Minute
 )
 
 var (
-	githubAPIBase string
-	defaultToken  string
+	githubAPIBase         string
+	defaultToken          string
+	rateLimitMaxWait      time.Duration
+	githubApp             *appAuth
+	defaultInstallationID int64
+
+	// githubResponseCache backs opt-in conditional-request caching for
+	// CallGitHubAPI (see cache.go).
+	githubResponseCache = newResponseCache(defaultCacheMaxEntries)
 )
 
-// CallGitHubAPI makes a generic call to the GitHub API
-func CallGitHubAPI(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[GitHubAPIParams]) (*mcp.CallToolResultFor[any], error) {
-	client := &http.Client{}
+// newGitHubClient builds a go-github client authenticated with token
+// (falling back to defaultToken), or with GitHub App auth if configured and
+// no token is available. It is a convenience wrapper around
+// newGitHubClientWithApp for the common case of tools that don't accept
+// per-call app overrides.
+func newGitHubClient(token string) (*github.Client, error) {
+	return newGitHubClientWithApp(context.Background(), token, 0, 0)
+}
 
-	// Validate endpoint
-	if params.Arguments.Endpoint == "" {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: endpoint is required"}},
-			IsError: true,
-		}, nil
+// newGitHubClientWithApp builds a go-github client pointed at githubAPIBase
+// when it differs from the public API. All tools share this constructor so
+// they share transport, auth, and rate limiting behavior via a common
+// RateLimitedTransport. Authentication is resolved in order: the given
+// token (falling back to defaultToken), then a GitHub App installation
+// token (appID/installationID falling back to the configured defaults),
+// then no authentication at all.
+func newGitHubClientWithApp(ctx context.Context, token string, appID, installationID int64) (*github.Client, error) {
+	if token == "" {
+		token = defaultToken
 	}
 
-	// Build URL
-	fullURL := params.Arguments.Endpoint
-	if !strings.HasPrefix(fullURL, "http") {
-		fullURL = githubAPIBase + "/" + strings.TrimPrefix(params.Arguments.Endpoint, "/")
+	var httpClient *http.Client
+	switch {
+	case token != "":
+		httpClient = forgeclient.NewHTTPClient(token, rateLimitMaxWait)
+	case githubApp != nil:
+		if appID != 0 && appID != githubApp.appID {
+			return nil, fmt.Errorf("unknown app_id %d: only %d is configured", appID, githubApp.appID)
+		}
+		instID := installationID
+		if instID == 0 {
+			instID = defaultInstallationID
+		}
+		if instID == 0 {
+			return nil, fmt.Errorf("GitHub App auth is configured but no installation ID was given (set GITHUB_APP_INSTALLATION_ID or pass installation_id)")
+		}
+		source := &installationTokenSource{ctx: ctx, auth: githubApp, installationID: instID}
+		httpClient = forgeclient.NewHTTPClientWithSource(source, rateLimitMaxWait)
+	default:
+		httpClient = forgeclient.NewHTTPClient("", rateLimitMaxWait)
 	}
 
-	// Validate URL
-	if _, err := url.Parse(fullURL); err != nil {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error: invalid URL: %v", err)}},
-			IsError: true,
-		}, nil
+	client := github.NewClient(httpClient)
+	client.UserAgent = userAgent
+
+	if githubAPIBase != "" && githubAPIBase != defaultGitHubAPIBase {
+		var err error
+		client, err = client.WithEnterpriseURLs(githubAPIBase, githubAPIBase)
+		if err != nil {
+			return nil, fmt.Errorf("configuring GitHub API base %q: %w", githubAPIBase, err)
+		}
 	}
 
-	// Determine method
-	method := params.Arguments.Method
-	if method == "" {
-		method = "GET"
+	return client, nil
+}
+
+// errorResult builds a CallToolResultFor carrying a single error text content
+func errorResult(format string, args ...any) (*mcp.CallToolResultFor[any], error) {
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf(format, args...)}},
+		IsError: true,
+	}, nil
+}
+
+// jsonResult marshals v as indented JSON and returns it as a single text content
+func jsonResult(v any) (*mcp.CallToolResultFor[any], error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return errorResult("Error marshaling response: %v", err)
 	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+	}, nil
+}
 
-	// Create request
-	var reqBody io.Reader
-	if params.Arguments.Body != nil && len(params.Arguments.Body) > 0 {
-		jsonBody, err := json.Marshal(params.Arguments.Body)
-		if err != nil {
+// apiErrorResult builds an error result from a failed API call, surfacing
+// a *common.MCPError (e.g. a rate-limit error raised by the transport) as
+// structured JSON so callers can inspect its Code and Details instead of
+// just a formatted message.
+func apiErrorResult(err error) (*mcp.CallToolResultFor[any], error) {
+	var mcpErr *common.MCPError
+	if errors.As(err, &mcpErr) {
+		data, merr := json.MarshalIndent(mcpErr, "", "  ")
+		if merr == nil {
 			return &mcp.CallToolResultFor[any]{
-				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error marshaling body: %v", err)}},
+				Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
 				IsError: true,
 			}, nil
 		}
-		reqBody = strings.NewReader(string(jsonBody))
 	}
+	return errorResult("Error: %v", err)
+}
 
-	req, err := http.NewRequestWithContext(ctx, method, fullURL, reqBody)
+// withRateLimitProgress augments ctx so that, should the shared
+// RateLimitedTransport need to sleep out a throttle, the wait is reported
+// back to the caller as an MCP progress notification instead of leaving
+// the tool call looking hung. progressToken is whatever the triggering
+// call's _meta.progressToken was (nil if the caller didn't request
+// progress updates), as returned by params.GetProgressToken().
+func withRateLimitProgress(ctx context.Context, session *mcp.ServerSession, progressToken any) context.Context {
+	return common.WithProgressNotifier(ctx, func(message string) {
+		log.Printf("github-proxy: %s", message)
+		if session == nil || progressToken == nil {
+			return
+		}
+		if err := session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+			ProgressToken: progressToken,
+			Message:       message,
+		}); err != nil {
+			log.Printf("github-proxy: sending progress notification: %v", err)
+		}
+	})
+}
+
+// CallGitHubAPI makes a generic call to the GitHub API. It is reimplemented
+// on top of the go-github client's Do method so it shares transport, auth,
+// and rate limiting behavior with the typed tools below.
+func CallGitHubAPI(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[GitHubAPIParams]) (*mcp.CallToolResultFor[any], error) {
+	if params.Arguments.Endpoint == "" {
+		return errorResult("Error: endpoint is required")
+	}
+	ctx = withRateLimitProgress(ctx, session, params.GetProgressToken())
+
+	client, err := newGitHubClientWithApp(ctx, params.Arguments.Token, params.Arguments.AppID, params.Arguments.InstallationID)
 	if err != nil {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error creating request: %v", err)}},
-			IsError: true,
-		}, nil
+		return errorResult("Error: %v", err)
 	}
 
-	// Set headers
-	req.Header.Set("User-Agent", userAgent)
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-	if reqBody != nil {
-		req.Header.Set("Content-Type", "application/json")
+	method := params.Arguments.Method
+	if method == "" {
+		method = "GET"
 	}
 
-	// Add authorization if token provided
-	token := params.Arguments.Token
-	if token == "" {
-		token = defaultToken
+	// client.NewRequest resolves relative endpoints against client.BaseURL;
+	// a full URL must instead be reduced to a path relative to it.
+	endpoint := params.Arguments.Endpoint
+	if strings.HasPrefix(endpoint, "http") {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return errorResult("Error: invalid URL: %v", err)
+		}
+		endpoint = strings.TrimPrefix(u.Path, "/")
+		if u.RawQuery != "" {
+			endpoint += "?" + u.RawQuery
+		}
+	} else {
+		endpoint = strings.TrimPrefix(endpoint, "/")
 	}
-	if token != "" {
-		req.Header.Set("Authorization", "token "+token)
+
+	var reqBody any
+	if len(params.Arguments.Body) > 0 {
+		reqBody = params.Arguments.Body
 	}
 
-	// Add custom headers
-	for k, v := range params.Arguments.Headers {
-		req.Header.Set(k, v)
+	var cacheTTL time.Duration
+	if params.Arguments.CacheTTL != "" {
+		var err error
+		cacheTTL, err = time.ParseDuration(params.Arguments.CacheTTL)
+		if err != nil {
+			return errorResult("Error: invalid cache_ttl: %v", err)
+		}
 	}
+	useCache := params.Arguments.Cache && method == http.MethodGet
 
-	// Make request
-	resp, err := client.Do(req)
-	if err != nil {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error making request: %v", err)}},
-			IsError: true,
-		}, nil
+	fetchPage := func(page int) ([]byte, *github.Response, error) {
+		pageEndpoint := endpoint
+		if page > 0 {
+			var err error
+			pageEndpoint, err = withPage(endpoint, page)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		var key string
+		var cached *cacheEntry
+		if useCache {
+			// Token alone isn't enough to distinguish callers: with GitHub App
+			// auth, the common case omits token entirely and selects an
+			// identity via app_id/installation_id instead, so those must be
+			// folded in too or two installations hitting the same endpoint
+			// would serve each other's cached responses.
+			auth := fmt.Sprintf("%s app:%d installation:%d", params.Arguments.Token, params.Arguments.AppID, params.Arguments.InstallationID)
+			key = cacheKey(method, pageEndpoint, auth)
+			cached, _ = githubResponseCache.get(key)
+		}
+
+		req, err := client.NewRequest(method, pageEndpoint, reqBody)
+		if err != nil {
+			return nil, nil, err
+		}
+		for k, v := range params.Arguments.Headers {
+			req.Header.Set(k, v)
+		}
+		if cached != nil {
+			if cached.ETag != "" {
+				req.Header.Set("If-None-Match", cached.ETag)
+			}
+			if cached.LastModified != "" {
+				req.Header.Set("If-Modified-Since", cached.LastModified)
+			}
+		}
+
+		var buf bytes.Buffer
+		resp, err := client.Do(ctx, req, &buf)
+		if err != nil {
+			// GitHub reports a successful conditional re-validation as a 304,
+			// which go-github surfaces as an error since it isn't a 2xx. That
+			// doesn't count against the rate limit, so serve the cached body
+			// instead of treating it as a real failure.
+			var ghErr *github.ErrorResponse
+			if cached != nil && errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusNotModified {
+				githubResponseCache.recordHit()
+				return cached.Body, &github.Response{Response: ghErr.Response}, nil
+			}
+			// go-github's Do returns before copying the body into v for any
+			// other non-2xx response, so buf is empty here: propagate err
+			// (typically a *github.ErrorResponse carrying the status and
+			// message) instead of returning an empty body as a success.
+			return nil, resp, err
+		}
+
+		if useCache && resp != nil && resp.StatusCode == http.StatusOK {
+			if etag, lastMod := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified"); etag != "" || lastMod != "" {
+				githubResponseCache.put(key, &cacheEntry{
+					Body:         buf.Bytes(),
+					ETag:         etag,
+					LastModified: lastMod,
+					StoredAt:     time.Now(),
+					TTL:          cacheTTL,
+				})
+			}
+			githubResponseCache.recordMiss()
+		}
+
+		return buf.Bytes(), resp, nil
 	}
-	defer resp.Body.Close()
 
-	// Read response
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
+	if !params.Arguments.AllPages && params.Arguments.MaxResults == 0 {
+		body, resp, err := fetchPage(0)
+		if err != nil {
+			return apiErrorResult(err)
+		}
+		status := resp.Status
+		if resp.StatusCode == http.StatusNotModified {
+			// body here is the full cached response (only 200s get cached),
+			// not an empty 304 body: report it as such instead of 304, which
+			// would otherwise look like an empty/failed call.
+			status = "200 OK (served from cache)"
+		}
+		response := fmt.Sprintf("Status: %s\n\n%s", status, string(body))
 		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Error reading response: %v", err)}},
-			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: response}},
 		}, nil
 	}
 
-	// Format response
-	response := fmt.Sprintf("Status: %s\n\n%s", resp.Status, string(body))
+	// Auto-pagination only makes sense for endpoints whose body is a JSON
+	// array; anything else is returned as a single page.
+	items, meta, err := paginate(params.Arguments.AllPages, params.Arguments.MaxResults, func(page int) ([]json.RawMessage, *github.Response, error) {
+		body, resp, err := fetchPage(page)
+		if err != nil {
+			return nil, nil, err
+		}
+		var items []json.RawMessage
+		if err := json.Unmarshal(body, &items); err != nil {
+			return nil, nil, fmt.Errorf("response body is not a JSON array, cannot paginate: %w", err)
+		}
+		return items, resp, nil
+	})
+	if err != nil {
+		return apiErrorResult(err)
+	}
 
-	return &mcp.CallToolResultFor[any]{
-		Content: []mcp.Content{&mcp.TextContent{Text: response}},
-	}, nil
+	return jsonResult(forgeclient.ListResult{Items: items, Pagination: meta})
 }
 
 // SearchRepos searches GitHub repositories
 func SearchRepos(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[SearchReposParams]) (*mcp.CallToolResultFor[any], error) {
 	if params.Arguments.Query == "" {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: query is required"}},
-			IsError: true,
-		}, nil
+		return errorResult("Error: query is required")
 	}
+	ctx = withRateLimitProgress(ctx, session, params.GetProgressToken())
 
-	// Build query parameters
-	queryParams := url.Values{}
-	queryParams.Set("q", params.Arguments.Query)
-	if params.Arguments.Sort != "" {
-		queryParams.Set("sort", params.Arguments.Sort)
+	client, err := newGitHubClient("")
+	if err != nil {
+		return errorResult("Error: %v", err)
 	}
-	if params.Arguments.Order != "" {
-		queryParams.Set("order", params.Arguments.Order)
+
+	var totalCount int
+	repos, meta, err := paginate(params.Arguments.AllPages, params.Arguments.MaxResults, func(page int) ([]*github.Repository, *github.Response, error) {
+		opts := &github.SearchOptions{
+			Sort:  params.Arguments.Sort,
+			Order: params.Arguments.Order,
+			ListOptions: github.ListOptions{
+				PerPage: params.Arguments.PerPage,
+				Page:    firstNonZero(page, params.Arguments.Page),
+			},
+		}
+		result, resp, err := client.Search.Repositories(ctx, params.Arguments.Query, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		totalCount = result.GetTotal()
+		return result.Repositories, resp, nil
+	})
+	if err != nil {
+		return apiErrorResult(err)
 	}
-	if params.Arguments.PerPage > 0 {
-		queryParams.Set("per_page", fmt.Sprintf("%d", params.Arguments.PerPage))
+	meta.TotalCount = totalCount
+
+	return jsonResult(forgeclient.ListResult{Items: repos, Pagination: meta})
+}
+
+// GetUser gets GitHub user information
+func GetUser(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[GetUserParams]) (*mcp.CallToolResultFor[any], error) {
+	if params.Arguments.Username == "" {
+		return errorResult("Error: username is required")
 	}
-	if params.Arguments.Page > 0 {
-		queryParams.Set("page", fmt.Sprintf("%d", params.Arguments.Page))
+	ctx = withRateLimitProgress(ctx, session, params.GetProgressToken())
+
+	client, err := newGitHubClient("")
+	if err != nil {
+		return errorResult("Error: %v", err)
 	}
 
-	endpoint := fmt.Sprintf("/search/repositories?%s", queryParams.Encode())
+	user, _, err := client.Users.Get(ctx, params.Arguments.Username)
+	if err != nil {
+		return apiErrorResult(err)
+	}
 
-	// Use the generic API caller
-	return CallGitHubAPI(ctx, session, &mcp.CallToolParamsFor[GitHubAPIParams]{
-		Arguments: GitHubAPIParams{
-			Endpoint: endpoint,
-		},
+	return jsonResult(user)
+}
+
+// GetRepo fetches a single repository
+func GetRepo(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[GetRepoParams]) (*mcp.CallToolResultFor[any], error) {
+	if params.Arguments.Owner == "" || params.Arguments.Repo == "" {
+		return errorResult("Error: owner and repo are required")
+	}
+	ctx = withRateLimitProgress(ctx, session, params.GetProgressToken())
+
+	client, err := newGitHubClient("")
+	if err != nil {
+		return errorResult("Error: %v", err)
+	}
+
+	repo, _, err := client.Repositories.Get(ctx, params.Arguments.Owner, params.Arguments.Repo)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+
+	return jsonResult(repo)
+}
+
+// ListIssues lists issues on a repository
+func ListIssues(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[ListIssuesParams]) (*mcp.CallToolResultFor[any], error) {
+	if params.Arguments.Owner == "" || params.Arguments.Repo == "" {
+		return errorResult("Error: owner and repo are required")
+	}
+	ctx = withRateLimitProgress(ctx, session, params.GetProgressToken())
+
+	client, err := newGitHubClient("")
+	if err != nil {
+		return errorResult("Error: %v", err)
+	}
+
+	var labels []string
+	if params.Arguments.Labels != "" {
+		labels = strings.Split(params.Arguments.Labels, ",")
+	}
+
+	issues, meta, err := paginate(params.Arguments.AllPages, params.Arguments.MaxResults, func(page int) ([]*github.Issue, *github.Response, error) {
+		opts := &github.IssueListByRepoOptions{
+			State:     params.Arguments.State,
+			Labels:    labels,
+			Sort:      params.Arguments.Sort,
+			Direction: params.Arguments.Direction,
+			ListOptions: github.ListOptions{
+				PerPage: params.Arguments.PerPage,
+				Page:    firstNonZero(page, params.Arguments.Page),
+			},
+		}
+		return client.Issues.ListByRepo(ctx, params.Arguments.Owner, params.Arguments.Repo, opts)
 	})
+	if err != nil {
+		return apiErrorResult(err)
+	}
+
+	return jsonResult(forgeclient.ListResult{Items: issues, Pagination: meta})
 }
 
-// GetUser gets GitHub user information
-func GetUser(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[GetUserParams]) (*mcp.CallToolResultFor[any], error) {
-	if params.Arguments.Username == "" {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "Error: username is required"}},
-			IsError: true,
-		}, nil
+// ListRepos lists repositories for a user or organization, or the
+// authenticated user's repositories when owner is omitted
+func ListRepos(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[ListReposParams]) (*mcp.CallToolResultFor[any], error) {
+	ctx = withRateLimitProgress(ctx, session, params.GetProgressToken())
+
+	client, err := newGitHubClient("")
+	if err != nil {
+		return errorResult("Error: %v", err)
 	}
 
-	endpoint := fmt.Sprintf("/users/%s", url.PathEscape(params.Arguments.Username))
+	repos, meta, err := paginate(params.Arguments.AllPages, params.Arguments.MaxResults, func(page int) ([]*github.Repository, *github.Response, error) {
+		opts := &github.RepositoryListOptions{
+			Type:      params.Arguments.Type,
+			Sort:      params.Arguments.Sort,
+			Direction: params.Arguments.Direction,
+			ListOptions: github.ListOptions{
+				PerPage: params.Arguments.PerPage,
+				Page:    firstNonZero(page, params.Arguments.Page),
+			},
+		}
+		return client.Repositories.List(ctx, params.Arguments.Owner, opts)
+	})
+	if err != nil {
+		return apiErrorResult(err)
+	}
+
+	return jsonResult(forgeclient.ListResult{Items: repos, Pagination: meta})
+}
 
-	// Use the generic API caller
-	return CallGitHubAPI(ctx, session, &mcp.CallToolParamsFor[GitHubAPIParams]{
-		Arguments: GitHubAPIParams{
-			Endpoint: endpoint,
+// ListPullRequests lists pull requests on a repository
+func ListPullRequests(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[ListPullRequestsParams]) (*mcp.CallToolResultFor[any], error) {
+	if params.Arguments.Owner == "" || params.Arguments.Repo == "" {
+		return errorResult("Error: owner and repo are required")
+	}
+	ctx = withRateLimitProgress(ctx, session, params.GetProgressToken())
+
+	client, err := newGitHubClient("")
+	if err != nil {
+		return errorResult("Error: %v", err)
+	}
+
+	opts := &github.PullRequestListOptions{
+		State:     params.Arguments.State,
+		Sort:      params.Arguments.Sort,
+		Direction: params.Arguments.Direction,
+		ListOptions: github.ListOptions{
+			PerPage: params.Arguments.PerPage,
+			Page:    params.Arguments.Page,
 		},
+	}
+
+	prs, _, err := client.PullRequests.List(ctx, params.Arguments.Owner, params.Arguments.Repo, opts)
+	if err != nil {
+		return apiErrorResult(err)
+	}
+
+	return jsonResult(prs)
+}
+
+// GetRelease fetches a repository release, by tag if given or the latest otherwise
+func GetRelease(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[GetReleaseParams]) (*mcp.CallToolResultFor[any], error) {
+	if params.Arguments.Owner == "" || params.Arguments.Repo == "" {
+		return errorResult("Error: owner and repo are required")
+	}
+	ctx = withRateLimitProgress(ctx, session, params.GetProgressToken())
+
+	client, err := newGitHubClient("")
+	if err != nil {
+		return errorResult("Error: %v", err)
+	}
+
+	var release *github.RepositoryRelease
+	if params.Arguments.Tag != "" {
+		release, _, err = client.Repositories.GetReleaseByTag(ctx, params.Arguments.Owner, params.Arguments.Repo, params.Arguments.Tag)
+	} else {
+		release, _, err = client.Repositories.GetLatestRelease(ctx, params.Arguments.Owner, params.Arguments.Repo)
+	}
+	if err != nil {
+		return apiErrorResult(err)
+	}
+
+	return jsonResult(release)
+}
+
+// ListWorkflowRuns lists GitHub Actions workflow runs for a repository
+func ListWorkflowRuns(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[ListWorkflowRunsParams]) (*mcp.CallToolResultFor[any], error) {
+	if params.Arguments.Owner == "" || params.Arguments.Repo == "" {
+		return errorResult("Error: owner and repo are required")
+	}
+	ctx = withRateLimitProgress(ctx, session, params.GetProgressToken())
+
+	client, err := newGitHubClient("")
+	if err != nil {
+		return errorResult("Error: %v", err)
+	}
+
+	opts := &github.ListWorkflowRunsOptions{
+		Branch: params.Arguments.Branch,
+		Status: params.Arguments.Status,
+		ListOptions: github.ListOptions{
+			PerPage: params.Arguments.PerPage,
+			Page:    params.Arguments.Page,
+		},
+	}
+
+	var runs *github.WorkflowRuns
+	if params.Arguments.WorkflowID != "" {
+		var workflowID int64
+		if _, err := fmt.Sscanf(params.Arguments.WorkflowID, "%d", &workflowID); err == nil && workflowID != 0 {
+			runs, _, err = client.Actions.ListWorkflowRunsByID(ctx, params.Arguments.Owner, params.Arguments.Repo, workflowID, opts)
+		} else {
+			runs, _, err = client.Actions.ListWorkflowRunsByFileName(ctx, params.Arguments.Owner, params.Arguments.Repo, params.Arguments.WorkflowID, opts)
+		}
+	} else {
+		runs, _, err = client.Actions.ListRepositoryWorkflowRuns(ctx, params.Arguments.Owner, params.Arguments.Repo, opts)
+	}
+	if err != nil {
+		return apiErrorResult(err)
+	}
+
+	return jsonResult(runs)
+}
+
+// ListInstallations lists the installations visible to the configured
+// GitHub App
+func ListInstallations(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[ListInstallationsParams]) (*mcp.CallToolResultFor[any], error) {
+	if githubApp == nil {
+		return errorResult("Error: GitHub App authentication is not configured (set GITHUB_APP_ID and GITHUB_APP_PRIVATE_KEY)")
+	}
+	ctx = withRateLimitProgress(ctx, session, params.GetProgressToken())
+
+	installations, _, err := githubApp.listInstallations(ctx, &github.ListOptions{
+		PerPage: params.Arguments.PerPage,
+		Page:    params.Arguments.Page,
 	})
+	if err != nil {
+		return apiErrorResult(err)
+	}
+
+	return jsonResult(installations)
+}
+
+// GitHubCacheStats reports hit/miss counts and bytes stored in the shared
+// conditional-request response cache used by CallGitHubAPI.
+func GitHubCacheStats(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[struct{}]) (*mcp.CallToolResultFor[any], error) {
+	return jsonResult(githubResponseCache.stats())
+}
+
+// configureGitHubApp reads the GITHUB_APP_* environment variables and, if
+// GITHUB_APP_ID is set, builds the package-level githubApp authenticator
+// used by newGitHubClientWithApp and the list_installations tool. It is a
+// no-op if GitHub App auth isn't configured.
+func configureGitHubApp() error {
+	appIDStr := os.Getenv("GITHUB_APP_ID")
+	if appIDStr == "" {
+		return nil
+	}
+
+	appID, err := strconv.ParseInt(appIDStr, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid GITHUB_APP_ID %q: %w", appIDStr, err)
+	}
+
+	keySource := os.Getenv("GITHUB_APP_PRIVATE_KEY")
+	if keySource == "" {
+		return fmt.Errorf("GITHUB_APP_ID is set but GITHUB_APP_PRIVATE_KEY is not")
+	}
+	privateKeyPEM := []byte(keySource)
+	if !strings.Contains(keySource, "PRIVATE KEY") {
+		privateKeyPEM, err = os.ReadFile(keySource)
+		if err != nil {
+			return fmt.Errorf("reading GITHUB_APP_PRIVATE_KEY as a file path: %w", err)
+		}
+	}
+
+	if v := os.Getenv("GITHUB_APP_INSTALLATION_ID"); v != "" {
+		defaultInstallationID, err = strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid GITHUB_APP_INSTALLATION_ID %q: %w", v, err)
+		}
+	}
+
+	// The authenticator mints tokens through an unauthenticated client
+	// sharing the same rate limiter as everything else. It is built directly
+	// rather than via newGitHubClient, which would fall back to GITHUB_TOKEN
+	// and overwrite the app JWT's Authorization header with it.
+	client := github.NewClient(forgeclient.NewHTTPClient("", rateLimitMaxWait))
+	client.UserAgent = userAgent
+	if githubAPIBase != "" && githubAPIBase != defaultGitHubAPIBase {
+		if client, err = client.WithEnterpriseURLs(githubAPIBase, githubAPIBase); err != nil {
+			return fmt.Errorf("configuring GitHub API base %q: %w", githubAPIBase, err)
+		}
+	}
+	githubApp, err = newAppAuth(appID, privateKeyPEM, client)
+	return err
 }
 
 func main() {
+	webhookAddr := flag.String("webhook-addr", "", "Address to listen on for GitHub webhooks (e.g. :8080); disabled if empty")
+	webhookPath := flag.String("webhook-path", "/webhooks/github", "Path GitHub webhooks are delivered to")
+	flag.Parse()
+
 	// Initialize configuration from environment
 	githubAPIBase = os.Getenv("GITHUB_API_BASE")
 	if githubAPIBase == "" {
@@ -210,16 +737,47 @@ func main() {
 	}
 	defaultToken = os.Getenv("GITHUB_TOKEN")
 
+	rateLimitMaxWait = defaultRateLimitMaxWait
+	if v := os.Getenv("GITHUB_RATE_LIMIT_MAX_WAIT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			rateLimitMaxWait = d
+		} else {
+			log.Printf("Ignoring invalid GITHUB_RATE_LIMIT_MAX_WAIT %q: %v", v, err)
+		}
+	}
+
+	if err := configureGitHubApp(); err != nil {
+		log.Fatalf("Configuring GitHub App auth: %v", err)
+	}
+
 	// Log configuration (without sensitive data)
 	log.Printf("Starting MCP GitHub Proxy Server...")
 	log.Printf("GitHub API Base: %s", githubAPIBase)
+	log.Printf("Rate limit max wait: %s", rateLimitMaxWait)
 	if defaultToken != "" {
 		log.Printf("Default GitHub token configured")
 	}
+	if githubApp != nil {
+		log.Printf("GitHub App auth configured (app ID %d)", githubApp.appID)
+	}
 
-	// Create server
 	server := mcp.NewServer("mcp-github-proxy", "v1.0.0", nil)
 
+	eventStore := newWebhookStore()
+	runCtx, stopWebhookListener := context.WithCancel(context.Background())
+	defer stopWebhookListener()
+	if *webhookAddr != "" {
+		secret := os.Getenv("GITHUB_WEBHOOK_SECRET")
+		if secret == "" {
+			log.Fatal("--webhook-addr was given but GITHUB_WEBHOOK_SECRET is not set")
+		}
+		go func() {
+			if err := startWebhookListener(runCtx, *webhookAddr, *webhookPath, []byte(secret), eventStore); err != nil {
+				log.Printf("github-proxy: webhook listener stopped: %v", err)
+			}
+		}()
+	}
+
 	// Add tools
 	server.AddTools(
 		mcp.NewServerTool("github_api", "Make a generic GitHub API call", CallGitHubAPI,
@@ -229,6 +787,12 @@ func main() {
 				mcp.Property("token", mcp.Description("GitHub personal access token (optional)")),
 				mcp.Property("body", mcp.Description("Request body for POST/PUT/PATCH requests")),
 				mcp.Property("headers", mcp.Description("Additional headers to include")),
+				mcp.Property("all_pages", mcp.Description("Follow the Link header and fetch every page (endpoint must return a JSON array)")),
+				mcp.Property("max_results", mcp.Description("Stop once this many items have been collected (implies all_pages)")),
+				mcp.Property("app_id", mcp.Description("GitHub App ID to authenticate as, if no token is given (optional, defaults to GITHUB_APP_ID)")),
+				mcp.Property("installation_id", mcp.Description("Installation ID to mint a GitHub App token for (optional, defaults to GITHUB_APP_INSTALLATION_ID)")),
+				mcp.Property("cache", mcp.Description("Cache this GET response and send If-None-Match/If-Modified-Since on repeat calls (304s don't count against the rate limit)")),
+				mcp.Property("cache_ttl", mcp.Description("Treat the cached entry as stale after this long, e.g. '10m' (optional, default: cached until evicted)")),
 			),
 		),
 		mcp.NewServerTool("search_repos", "Search GitHub repositories", SearchRepos,
@@ -238,6 +802,20 @@ func main() {
 				mcp.Property("order", mcp.Description("Order: asc or desc")),
 				mcp.Property("per_page", mcp.Description("Results per page (max 100)")),
 				mcp.Property("page", mcp.Description("Page number")),
+				mcp.Property("all_pages", mcp.Description("Fetch every page of results")),
+				mcp.Property("max_results", mcp.Description("Stop once this many results have been collected (implies all_pages)")),
+			),
+		),
+		mcp.NewServerTool("list_repos", "List repositories for a user, organization, or the authenticated user", ListRepos,
+			mcp.Input(
+				mcp.Property("owner", mcp.Description("User or organization login (omit for the authenticated user's repositories)")),
+				mcp.Property("type", mcp.Description("Type: all, owner, member")),
+				mcp.Property("sort", mcp.Description("Sort by: created, updated, pushed, full_name")),
+				mcp.Property("direction", mcp.Description("Direction: asc or desc")),
+				mcp.Property("per_page", mcp.Description("Results per page (max 100)")),
+				mcp.Property("page", mcp.Description("Page number")),
+				mcp.Property("all_pages", mcp.Description("Fetch every page of results")),
+				mcp.Property("max_results", mcp.Description("Stop once this many repositories have been collected (implies all_pages)")),
 			),
 		),
 		mcp.NewServerTool("get_user", "Get GitHub user information", GetUser,
@@ -245,6 +823,84 @@ func main() {
 				mcp.Property("username", mcp.Description("GitHub username")),
 			),
 		),
+		mcp.NewServerTool("get_repo", "Get a GitHub repository", GetRepo,
+			mcp.Input(
+				mcp.Property("owner", mcp.Description("Repository owner")),
+				mcp.Property("repo", mcp.Description("Repository name")),
+			),
+		),
+		mcp.NewServerTool("list_issues", "List issues on a GitHub repository", ListIssues,
+			mcp.Input(
+				mcp.Property("owner", mcp.Description("Repository owner")),
+				mcp.Property("repo", mcp.Description("Repository name")),
+				mcp.Property("state", mcp.Description("Issue state: open, closed, or all")),
+				mcp.Property("labels", mcp.Description("Comma-separated list of label names")),
+				mcp.Property("sort", mcp.Description("Sort by: created, updated, comments")),
+				mcp.Property("direction", mcp.Description("Direction: asc or desc")),
+				mcp.Property("per_page", mcp.Description("Results per page (max 100)")),
+				mcp.Property("page", mcp.Description("Page number")),
+				mcp.Property("all_pages", mcp.Description("Fetch every page of results")),
+				mcp.Property("max_results", mcp.Description("Stop once this many issues have been collected (implies all_pages)")),
+			),
+		),
+		mcp.NewServerTool("list_pull_requests", "List pull requests on a GitHub repository", ListPullRequests,
+			mcp.Input(
+				mcp.Property("owner", mcp.Description("Repository owner")),
+				mcp.Property("repo", mcp.Description("Repository name")),
+				mcp.Property("state", mcp.Description("PR state: open, closed, or all")),
+				mcp.Property("sort", mcp.Description("Sort by: created, updated, popularity, long-running")),
+				mcp.Property("direction", mcp.Description("Direction: asc or desc")),
+				mcp.Property("per_page", mcp.Description("Results per page (max 100)")),
+				mcp.Property("page", mcp.Description("Page number")),
+			),
+		),
+		mcp.NewServerTool("get_release", "Get a GitHub repository release", GetRelease,
+			mcp.Input(
+				mcp.Property("owner", mcp.Description("Repository owner")),
+				mcp.Property("repo", mcp.Description("Repository name")),
+				mcp.Property("tag", mcp.Description("Release tag (omit for the latest release)")),
+			),
+		),
+		mcp.NewServerTool("list_workflow_runs", "List GitHub Actions workflow runs for a repository", ListWorkflowRuns,
+			mcp.Input(
+				mcp.Property("owner", mcp.Description("Repository owner")),
+				mcp.Property("repo", mcp.Description("Repository name")),
+				mcp.Property("workflow_id", mcp.Description("Workflow ID or file name (omit to list runs for all workflows)")),
+				mcp.Property("branch", mcp.Description("Filter by branch name")),
+				mcp.Property("status", mcp.Description("Filter by status: queued, in_progress, completed, etc.")),
+				mcp.Property("per_page", mcp.Description("Results per page (max 100)")),
+				mcp.Property("page", mcp.Description("Page number")),
+			),
+		),
+		mcp.NewServerTool("list_installations", "List installations visible to the configured GitHub App", ListInstallations,
+			mcp.Input(
+				mcp.Property("per_page", mcp.Description("Results per page (max 100)")),
+				mcp.Property("page", mcp.Description("Page number")),
+			),
+		),
+		mcp.NewServerTool("github_cache_stats", "Report hit/miss counts and bytes stored in the conditional-request response cache", GitHubCacheStats),
+	)
+
+	// Add resource templates for webhook-delivered events, backed by
+	// eventStore regardless of whether the webhook listener is enabled
+	// (so reads just come back empty until it is).
+	server.AddResourceTemplates(
+		&mcp.ServerResourceTemplate{
+			ResourceTemplate: &mcp.ResourceTemplate{
+				URITemplate: "github://events/{owner}/{repo}",
+				Name:        "GitHub Repository Events",
+				Description: "Recent GitHub webhook deliveries for a repository, oldest first",
+			},
+			Handler: githubEventsResourceHandler(eventStore),
+		},
+		&mcp.ServerResourceTemplate{
+			ResourceTemplate: &mcp.ResourceTemplate{
+				URITemplate: "github://events/{owner}/{repo}/latest",
+				Name:        "Latest GitHub Repository Event",
+				Description: "The most recent GitHub webhook delivery for a repository",
+			},
+			Handler: githubEventsResourceHandler(eventStore),
+		},
 	)
 
 	// Add resources
@@ -259,9 +915,10 @@ func main() {
 				docs := fmt.Sprintf(`GitHub Proxy MCP Server
 =======================
 
-This server provides a proxy to the GitHub API with the following tools:
+This server provides a proxy to the GitHub API, backed by go-github, with
+the following tools:
 
-1. github_api - Make generic GitHub API calls
+1. github_api - Make a generic GitHub API call (escape hatch)
    - endpoint: API endpoint path or full URL
    - method: HTTP method (default: GET)
    - token: GitHub personal access token (optional, uses GITHUB_TOKEN env if not provided)
@@ -278,6 +935,61 @@ This server provides a proxy to the GitHub API with the following tools:
 3. get_user - Get GitHub user information
    - username: GitHub username (required)
 
+4. get_repo - Get a GitHub repository
+   - owner, repo: Repository coordinates (required)
+
+5. list_issues - List issues on a repository
+   - owner, repo: Repository coordinates (required)
+   - state, labels, sort, direction, per_page, page, all_pages, max_results
+
+6. list_pull_requests - List pull requests on a repository
+   - owner, repo: Repository coordinates (required)
+   - state, sort, direction, per_page, page
+
+7. get_release - Get a repository release
+   - owner, repo: Repository coordinates (required)
+   - tag: Release tag (omit for the latest release)
+
+8. list_workflow_runs - List GitHub Actions workflow runs
+   - owner, repo: Repository coordinates (required)
+   - workflow_id, branch, status, per_page, page
+
+9. list_repos - List repositories for a user, organization, or the authenticated user
+   - owner: User or organization login (omit for the authenticated user)
+   - type, sort, direction, per_page, page, all_pages, max_results
+
+10. list_installations - List installations visible to the configured GitHub App
+   - per_page, page
+   - requires GITHUB_APP_ID and GITHUB_APP_PRIVATE_KEY to be set
+
+11. github_cache_stats - Report hit/miss counts and bytes stored in the
+    conditional-request response cache (no parameters)
+
+Pagination:
+- search_repos, list_issues, list_repos, and github_api accept all_pages
+  (follow the Link header across every page) and max_results (stop once
+  that many items have been collected). Results are returned alongside a
+  "pagination" object (page, next_page, prev_page, first_page, last_page,
+  total_count) describing the page(s) that were fetched.
+
+Conditional request caching:
+- github_api accepts cache (cache this GET response, and revalidate it with
+  If-None-Match/If-Modified-Since on later calls to the same endpoint) and
+  cache_ttl (treat the cached entry as stale after this long, e.g. "10m").
+  A 304 response serves the cached body and does not count against the
+  rate limit. github_cache_stats reports how well this is working.
+
+Webhook-driven event resources:
+- Run with -webhook-addr (e.g. -webhook-addr :8080) to also listen for
+  GitHub webhook deliveries (HMAC-verified against GITHUB_WEBHOOK_SECRET)
+  at -webhook-path (default /webhooks/github), alongside the stdio
+  transport used for everything else.
+- github://events/{owner}/{repo} surfaces the last 50 deliveries received
+  for that repository, oldest first; github://events/{owner}/{repo}/latest
+  surfaces only the most recent one.
+  There's no push notification when a new one arrives: re-read the
+  resource to pick up deliveries received since the last read.
+
 Configuration:
 - API Base: %s
 - Default Token: %s
@@ -285,10 +997,23 @@ Configuration:
 Environment Variables:
 - GITHUB_TOKEN: Default GitHub personal access token
 - GITHUB_API_BASE: Custom GitHub API base URL (for GitHub Enterprise)
+- GITHUB_RATE_LIMIT_MAX_WAIT: Longest a call will sleep for a rate limit to
+  reset before failing fast with a RATE_LIMITED error (default: 2m)
+- GITHUB_APP_ID: GitHub App ID, to authenticate as a GitHub App instead of
+  (or as a fallback from) a personal access token
+- GITHUB_APP_PRIVATE_KEY: The app's private key, as PEM text or a path to a
+  PEM file
+- GITHUB_APP_INSTALLATION_ID: Default installation ID to mint tokens for
+  (can be overridden per-call via installation_id)
+- GITHUB_WEBHOOK_SECRET: Secret used to verify webhook deliveries; required
+  when -webhook-addr is set
 
 Rate Limiting:
 - Unauthenticated: 60 requests/hour
-- Authenticated: 5,000 requests/hour`, githubAPIBase, func() string {
+- Authenticated: 5,000 requests/hour
+- All tools share a rate limiter: once a response reports the budget is
+  exhausted, subsequent calls sleep until reset (bounded by
+  GITHUB_RATE_LIMIT_MAX_WAIT) rather than failing immediately`, githubAPIBase, func() string {
 					if defaultToken != "" {
 						return "Configured"
 					}
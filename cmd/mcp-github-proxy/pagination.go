@@ -0,0 +1,57 @@
+package main
+
+import (
+	"github.com/google/go-github/v56/github"
+
+	"github.com/shuymn/mcp/internal/forgeclient"
+)
+
+// pageInfo converts a go-github response's parsed Link header (go-github
+// already does this parsing for us on every request, typed or raw) into a
+// forge-agnostic forgeclient.PageInfo. Unlike go-gitlab, go-github's
+// Response has no field for the page that was actually fetched, so it's
+// derived from its neighbors in the Link header instead.
+func pageInfo(resp *github.Response) forgeclient.PageInfo {
+	if resp == nil {
+		return forgeclient.PageInfo{}
+	}
+	info := forgeclient.PageInfo{
+		NextPage:  resp.NextPage,
+		PrevPage:  resp.PrevPage,
+		FirstPage: resp.FirstPage,
+		LastPage:  resp.LastPage,
+	}
+	switch {
+	case resp.PrevPage != 0:
+		info.Page = resp.PrevPage + 1
+	case resp.NextPage != 0:
+		info.Page = resp.NextPage - 1
+	default:
+		info.Page = 1
+	}
+	return info
+}
+
+// paginate drives fetch across successive pages, translating go-github's
+// *github.Response into forgeclient.PageInfo so the page-walking logic can
+// live in one place shared with the GitLab proxy.
+func paginate[T any](allPages bool, maxResults int, fetch func(page int) ([]T, *github.Response, error)) ([]T, forgeclient.PageInfo, error) {
+	return forgeclient.Paginate(allPages, maxResults, func(page int) ([]T, forgeclient.PageInfo, error) {
+		items, resp, err := fetch(page)
+		if err != nil {
+			return nil, forgeclient.PageInfo{}, err
+		}
+		return items, pageInfo(resp), nil
+	})
+}
+
+// firstNonZero returns the first of a, b that is non-zero, or zero if both are.
+func firstNonZero(a, b int) int {
+	return forgeclient.FirstNonZero(a, b)
+}
+
+// withPage returns endpoint with its "page" query parameter set, preserving
+// any other query parameters already present.
+func withPage(endpoint string, page int) (string, error) {
+	return forgeclient.WithPage(endpoint, page)
+}
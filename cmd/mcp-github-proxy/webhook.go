@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v56/github"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// defaultEventRingSize bounds how many recent webhook deliveries are kept
+// per repository.
+const defaultEventRingSize = 50
+
+// webhookEvent is a single stored GitHub webhook delivery.
+type webhookEvent struct {
+	Type       string    `json:"type"`
+	ReceivedAt time.Time `json:"received_at"`
+	Payload    any       `json:"payload"`
+}
+
+// eventRing is a fixed-size ring buffer of the most recent webhook
+// deliveries for one repository.
+type eventRing struct {
+	mu     sync.Mutex
+	events []webhookEvent
+	size   int
+	next   int
+	full   bool
+}
+
+func newEventRing(size int) *eventRing {
+	return &eventRing{events: make([]webhookEvent, size), size: size}
+}
+
+func (r *eventRing) add(e webhookEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events[r.next] = e
+	r.next = (r.next + 1) % r.size
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// all returns the buffered events, oldest first.
+func (r *eventRing) all() []webhookEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.full {
+		out := make([]webhookEvent, r.next)
+		copy(out, r.events[:r.next])
+		return out
+	}
+	out := make([]webhookEvent, r.size)
+	copy(out, r.events[r.next:])
+	copy(out[r.size-r.next:], r.events[:r.next])
+	return out
+}
+
+// latest returns the most recently added event, if any.
+func (r *eventRing) latest() (webhookEvent, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.full && r.next == 0 {
+		return webhookEvent{}, false
+	}
+	idx := (r.next - 1 + r.size) % r.size
+	return r.events[idx], true
+}
+
+// webhookStore tracks recent GitHub webhook deliveries per repository. The
+// go-sdk version this proxy builds against doesn't support
+// resources/subscribe, so there's no way to push a notification when a new
+// delivery lands: clients learn about it by re-reading the events
+// resources, which always reflect the latest state in the ring buffer.
+type webhookStore struct {
+	mu    sync.Mutex
+	repos map[string]*eventRing // "owner/repo" -> ring buffer
+}
+
+func newWebhookStore() *webhookStore {
+	return &webhookStore{
+		repos: make(map[string]*eventRing),
+	}
+}
+
+func (s *webhookStore) ringFor(repo string) *eventRing {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.repos[repo]
+	if !ok {
+		r = newEventRing(defaultEventRingSize)
+		s.repos[repo] = r
+	}
+	return r
+}
+
+// record appends event to repo's ring buffer.
+func (s *webhookStore) record(repo string, event webhookEvent) {
+	s.ringFor(repo).add(event)
+}
+
+// repoFromEvent extracts "owner/repo" from the subset of webhook event
+// types that carry a repository (pushes, issues, pull requests, workflow
+// runs, ...), or "" for the ones that don't (e.g. marketplace_purchase).
+func repoFromEvent(event any) string {
+	type repoGetter interface {
+		GetRepo() *github.Repository
+	}
+	rg, ok := event.(repoGetter)
+	if !ok {
+		return ""
+	}
+	repo := rg.GetRepo()
+	if repo == nil {
+		return ""
+	}
+	return repo.GetFullName()
+}
+
+// webhookHandler verifies and parses incoming GitHub webhook deliveries
+// with secret (via github.ValidatePayload and github.ParseWebHook) and
+// records them against the repository they target.
+func webhookHandler(store *webhookStore, secret []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		payload, err := github.ValidatePayload(r, secret)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid webhook payload: %v", err), http.StatusUnauthorized)
+			return
+		}
+
+		eventType := github.WebHookType(r)
+		event, err := github.ParseWebHook(eventType, payload)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("parsing webhook payload: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		repo := repoFromEvent(event)
+		if repo == "" {
+			// No repository to file this under (e.g. a marketplace_purchase
+			// or membership event); acknowledge it and move on.
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		store.record(repo, webhookEvent{
+			Type:       eventType,
+			ReceivedAt: time.Now(),
+			Payload:    event,
+		})
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// startWebhookListener runs an HTTP server receiving GitHub webhooks at
+// addr/path until ctx is canceled, recording deliveries into store.
+func startWebhookListener(ctx context.Context, addr, path string, secret []byte, store *webhookStore) error {
+	mux := http.NewServeMux()
+	mux.Handle(path, webhookHandler(store, secret))
+	server := &http.Server{Addr: addr, Handler: mux}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("github-proxy: webhook listener on %s%s", addr, path)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// githubEventsResourceHandler serves github://events/{owner}/{repo} (the
+// full ring buffer, oldest first) and github://events/{owner}/{repo}/latest
+// (just the most recent delivery) from store.
+func githubEventsResourceHandler(store *webhookStore) func(ctx context.Context, session *mcp.ServerSession, params *mcp.ReadResourceParams) (*mcp.ReadResourceResult, error) {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.ReadResourceParams) (*mcp.ReadResourceResult, error) {
+		rest := strings.TrimPrefix(params.URI, "github://events/")
+		latestOnly := false
+		if trimmed := strings.TrimSuffix(rest, "/latest"); trimmed != rest {
+			latestOnly = true
+			rest = trimmed
+		}
+		owner, repoName, ok := strings.Cut(rest, "/")
+		if !ok || owner == "" || repoName == "" {
+			return nil, fmt.Errorf("invalid events URI %q: expected github://events/{owner}/{repo}[/latest]", params.URI)
+		}
+		repo := owner + "/" + repoName
+
+		var data any
+		if latestOnly {
+			if event, ok := store.ringFor(repo).latest(); ok {
+				data = event
+			}
+		} else {
+			data = store.ringFor(repo).all()
+		}
+
+		text, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("marshaling events: %w", err)
+		}
+
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{
+				{
+					URI:      params.URI,
+					MIMEType: "application/json",
+					Text:     string(text),
+				},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,584 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/shuymn/mcp/internal/common"
+	"github.com/shuymn/mcp/internal/forgeclient"
+)
+
+// GitLabAPIParams defines parameters for generic GitLab API calls
+type GitLabAPIParams struct {
+	Endpoint   string            `json:"endpoint"`
+	Method     string            `json:"method,omitempty"`
+	Token      string            `json:"token,omitempty"`
+	Body       map[string]any    `json:"body,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	AllPages   bool              `json:"all_pages,omitempty"`
+	MaxResults int               `json:"max_results,omitempty"`
+}
+
+// SearchProjectsParams defines parameters for searching GitLab projects
+type SearchProjectsParams struct {
+	Query      string `json:"query"`
+	PerPage    int    `json:"per_page,omitempty"`
+	Page       int    `json:"page,omitempty"`
+	AllPages   bool   `json:"all_pages,omitempty"`
+	MaxResults int    `json:"max_results,omitempty"`
+}
+
+// GetUserParams defines parameters for getting user information
+type GetUserParams struct {
+	Username string `json:"username"`
+}
+
+// ListMergeRequestsParams defines parameters for listing merge requests on a project
+type ListMergeRequestsParams struct {
+	Project      string `json:"project"`
+	State        string `json:"state,omitempty"`
+	TargetBranch string `json:"target_branch,omitempty"`
+	SourceBranch string `json:"source_branch,omitempty"`
+	PerPage      int    `json:"per_page,omitempty"`
+	Page         int    `json:"page,omitempty"`
+	AllPages     bool   `json:"all_pages,omitempty"`
+	MaxResults   int    `json:"max_results,omitempty"`
+}
+
+// ListPipelinesParams defines parameters for listing pipelines on a project
+type ListPipelinesParams struct {
+	Project    string `json:"project"`
+	Status     string `json:"status,omitempty"`
+	Ref        string `json:"ref,omitempty"`
+	PerPage    int    `json:"per_page,omitempty"`
+	Page       int    `json:"page,omitempty"`
+	AllPages   bool   `json:"all_pages,omitempty"`
+	MaxResults int    `json:"max_results,omitempty"`
+}
+
+const (
+	defaultGitLabURL        = "https://gitlab.com"
+	userAgent               = "MCP-GitLab-Proxy/1.0"
+	defaultRateLimitMaxWait = 2 * time.Minute
+)
+
+var (
+	gitlabURL        string
+	defaultToken     string
+	rateLimitMaxWait time.Duration
+)
+
+// newGitLabClient builds a go-gitlab client authenticated with token
+// (falling back to defaultToken) and pointed at gitlabURL when it differs
+// from the public instance. All tools share this constructor so they share
+// transport and rate limiting behavior via a common RateLimitedTransport.
+// Unlike the GitHub proxy, the token itself is handed to go-gitlab rather
+// than baked into the http.Client: go-gitlab sets the PRIVATE-TOKEN header
+// itself, so the shared client here only needs to add rate limiting.
+func newGitLabClient(token string) (*gitlab.Client, error) {
+	if token == "" {
+		token = defaultToken
+	}
+
+	httpClient := forgeclient.NewRateLimitedHTTPClient(rateLimitMaxWait)
+	httpClient.Transport = &userAgentTransport{base: httpClient.Transport, userAgent: userAgent}
+	opts := []gitlab.ClientOptionFunc{
+		gitlab.WithHTTPClient(httpClient),
+	}
+	if gitlabURL != "" && gitlabURL != defaultGitLabURL {
+		opts = append(opts, gitlab.WithBaseURL(gitlabURL))
+	}
+
+	client, err := gitlab.NewClient(token, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("building GitLab client: %w", err)
+	}
+	return client, nil
+}
+
+// userAgentTransport sets a static User-Agent on every request. go-gitlab
+// has no client option for this (unlike go-github's client.UserAgent
+// field), so the proxy sets it at the transport level instead, layered on
+// top of the shared rate-limited transport.
+type userAgentTransport struct {
+	base      http.RoundTripper
+	userAgent string
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.base.RoundTrip(req)
+}
+
+// errorResult builds a CallToolResultFor carrying a single error text content
+func errorResult(format string, args ...any) (*mcp.CallToolResultFor[any], error) {
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf(format, args...)}},
+		IsError: true,
+	}, nil
+}
+
+// jsonResult marshals v as indented JSON and returns it as a single text content
+func jsonResult(v any) (*mcp.CallToolResultFor[any], error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return errorResult("Error marshaling response: %v", err)
+	}
+	return &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+	}, nil
+}
+
+// apiErrorResult builds an error result from a failed API call, surfacing
+// a *common.MCPError (e.g. a rate-limit error raised by the transport) as
+// structured JSON so callers can inspect its Code and Details instead of
+// just a formatted message.
+func apiErrorResult(err error) (*mcp.CallToolResultFor[any], error) {
+	var mcpErr *common.MCPError
+	if errors.As(err, &mcpErr) {
+		data, merr := json.MarshalIndent(mcpErr, "", "  ")
+		if merr == nil {
+			return &mcp.CallToolResultFor[any]{
+				Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+				IsError: true,
+			}, nil
+		}
+	}
+	return errorResult("Error: %v", err)
+}
+
+// withRateLimitProgress augments ctx so that, should the shared
+// RateLimitedTransport need to sleep out a throttle, the wait is reported
+// back to the caller as an MCP progress notification instead of leaving
+// the tool call looking hung. progressToken is whatever the triggering
+// call's _meta.progressToken was (nil if the caller didn't request
+// progress updates), as returned by params.GetProgressToken().
+func withRateLimitProgress(ctx context.Context, session *mcp.ServerSession, progressToken any) context.Context {
+	return common.WithProgressNotifier(ctx, func(message string) {
+		log.Printf("gitlab-proxy: %s", message)
+		if session == nil || progressToken == nil {
+			return
+		}
+		if err := session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+			ProgressToken: progressToken,
+			Message:       message,
+		}); err != nil {
+			log.Printf("gitlab-proxy: sending progress notification: %v", err)
+		}
+	})
+}
+
+// CallGitLabAPI makes a generic call to the GitLab API. It is reimplemented
+// on top of the go-gitlab client's Do method so it shares transport and
+// rate limiting behavior with the typed tools below.
+func CallGitLabAPI(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[GitLabAPIParams]) (*mcp.CallToolResultFor[any], error) {
+	if params.Arguments.Endpoint == "" {
+		return errorResult("Error: endpoint is required")
+	}
+	ctx = withRateLimitProgress(ctx, session, params.GetProgressToken())
+
+	client, err := newGitLabClient(params.Arguments.Token)
+	if err != nil {
+		return errorResult("Error: %v", err)
+	}
+
+	method := params.Arguments.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	// client.NewRequest resolves relative endpoints against the client's
+	// base URL; a full URL must instead be reduced to a path relative to it.
+	endpoint := params.Arguments.Endpoint
+	if strings.HasPrefix(endpoint, "http") {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return errorResult("Error: invalid URL: %v", err)
+		}
+		endpoint = strings.TrimPrefix(u.Path, "/")
+		if u.RawQuery != "" {
+			endpoint += "?" + u.RawQuery
+		}
+	} else {
+		endpoint = strings.TrimPrefix(endpoint, "/")
+	}
+
+	var reqBody any
+	if len(params.Arguments.Body) > 0 {
+		reqBody = params.Arguments.Body
+	}
+
+	fetchPage := func(page int) ([]byte, *gitlab.Response, error) {
+		pageEndpoint := endpoint
+		if page > 0 {
+			var err error
+			pageEndpoint, err = withPage(endpoint, page)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		req, err := client.NewRequest(method, pageEndpoint, reqBody, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		for k, v := range params.Arguments.Headers {
+			req.Header.Set(k, v)
+		}
+
+		var buf bytes.Buffer
+		resp, err := client.Do(req, &buf)
+		if err != nil {
+			// Like go-github, go-gitlab's Do returns before copying the body
+			// into v on any non-2xx response, so buf is empty here: propagate
+			// err instead of returning an empty body as a success.
+			return nil, resp, err
+		}
+		return buf.Bytes(), resp, nil
+	}
+
+	if !params.Arguments.AllPages && params.Arguments.MaxResults == 0 {
+		body, resp, err := fetchPage(0)
+		if err != nil {
+			return apiErrorResult(err)
+		}
+		response := fmt.Sprintf("Status: %s\n\n%s", resp.Status, string(body))
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: response}},
+		}, nil
+	}
+
+	// Auto-pagination only makes sense for endpoints whose body is a JSON
+	// array; anything else is returned as a single page.
+	items, meta, err := paginate(params.Arguments.AllPages, params.Arguments.MaxResults, func(page int) ([]json.RawMessage, *gitlab.Response, error) {
+		body, resp, err := fetchPage(page)
+		if err != nil {
+			return nil, nil, err
+		}
+		var items []json.RawMessage
+		if err := json.Unmarshal(body, &items); err != nil {
+			return nil, nil, fmt.Errorf("response body is not a JSON array, cannot paginate: %w", err)
+		}
+		return items, resp, nil
+	})
+	if err != nil {
+		return apiErrorResult(err)
+	}
+
+	return jsonResult(forgeclient.ListResult{Items: items, Pagination: meta})
+}
+
+// SearchProjects searches GitLab projects
+func SearchProjects(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[SearchProjectsParams]) (*mcp.CallToolResultFor[any], error) {
+	if params.Arguments.Query == "" {
+		return errorResult("Error: query is required")
+	}
+	ctx = withRateLimitProgress(ctx, session, params.GetProgressToken())
+
+	client, err := newGitLabClient("")
+	if err != nil {
+		return errorResult("Error: %v", err)
+	}
+
+	projects, meta, err := paginate(params.Arguments.AllPages, params.Arguments.MaxResults, func(page int) ([]*gitlab.Project, *gitlab.Response, error) {
+		opts := &gitlab.SearchOptions{
+			ListOptions: gitlab.ListOptions{
+				PerPage: params.Arguments.PerPage,
+				Page:    firstNonZero(page, params.Arguments.Page),
+			},
+		}
+		return client.Search.Projects(params.Arguments.Query, opts, gitlab.WithContext(ctx))
+	})
+	if err != nil {
+		return apiErrorResult(err)
+	}
+
+	return jsonResult(forgeclient.ListResult{Items: projects, Pagination: meta})
+}
+
+// GetUser gets GitLab user information. GitLab has no "get user by
+// username" endpoint, so this lists users filtered by username and
+// returns the single match.
+func GetUser(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[GetUserParams]) (*mcp.CallToolResultFor[any], error) {
+	if params.Arguments.Username == "" {
+		return errorResult("Error: username is required")
+	}
+	ctx = withRateLimitProgress(ctx, session, params.GetProgressToken())
+
+	client, err := newGitLabClient("")
+	if err != nil {
+		return errorResult("Error: %v", err)
+	}
+
+	users, _, err := client.Users.ListUsers(&gitlab.ListUsersOptions{
+		Username: gitlab.Ptr(params.Arguments.Username),
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		return apiErrorResult(err)
+	}
+	if len(users) == 0 {
+		return errorResult("Error: user %q not found", params.Arguments.Username)
+	}
+
+	return jsonResult(users[0])
+}
+
+// ListMergeRequests lists merge requests on a project
+func ListMergeRequests(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[ListMergeRequestsParams]) (*mcp.CallToolResultFor[any], error) {
+	if params.Arguments.Project == "" {
+		return errorResult("Error: project is required")
+	}
+	ctx = withRateLimitProgress(ctx, session, params.GetProgressToken())
+
+	client, err := newGitLabClient("")
+	if err != nil {
+		return errorResult("Error: %v", err)
+	}
+
+	var state *string
+	if params.Arguments.State != "" {
+		state = gitlab.Ptr(params.Arguments.State)
+	}
+	var targetBranch *string
+	if params.Arguments.TargetBranch != "" {
+		targetBranch = gitlab.Ptr(params.Arguments.TargetBranch)
+	}
+	var sourceBranch *string
+	if params.Arguments.SourceBranch != "" {
+		sourceBranch = gitlab.Ptr(params.Arguments.SourceBranch)
+	}
+
+	mrs, meta, err := paginate(params.Arguments.AllPages, params.Arguments.MaxResults, func(page int) ([]*gitlab.MergeRequest, *gitlab.Response, error) {
+		opts := &gitlab.ListProjectMergeRequestsOptions{
+			State:        state,
+			TargetBranch: targetBranch,
+			SourceBranch: sourceBranch,
+			ListOptions: gitlab.ListOptions{
+				PerPage: params.Arguments.PerPage,
+				Page:    firstNonZero(page, params.Arguments.Page),
+			},
+		}
+		return client.MergeRequests.ListProjectMergeRequests(params.Arguments.Project, opts, gitlab.WithContext(ctx))
+	})
+	if err != nil {
+		return apiErrorResult(err)
+	}
+
+	return jsonResult(forgeclient.ListResult{Items: mrs, Pagination: meta})
+}
+
+// ListPipelines lists pipelines on a project
+func ListPipelines(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[ListPipelinesParams]) (*mcp.CallToolResultFor[any], error) {
+	if params.Arguments.Project == "" {
+		return errorResult("Error: project is required")
+	}
+	ctx = withRateLimitProgress(ctx, session, params.GetProgressToken())
+
+	client, err := newGitLabClient("")
+	if err != nil {
+		return errorResult("Error: %v", err)
+	}
+
+	var status *gitlab.BuildStateValue
+	if params.Arguments.Status != "" {
+		status = gitlab.Ptr(gitlab.BuildStateValue(params.Arguments.Status))
+	}
+	var ref *string
+	if params.Arguments.Ref != "" {
+		ref = gitlab.Ptr(params.Arguments.Ref)
+	}
+
+	pipelines, meta, err := paginate(params.Arguments.AllPages, params.Arguments.MaxResults, func(page int) ([]*gitlab.PipelineInfo, *gitlab.Response, error) {
+		opts := &gitlab.ListProjectPipelinesOptions{
+			Status: status,
+			Ref:    ref,
+			ListOptions: gitlab.ListOptions{
+				PerPage: params.Arguments.PerPage,
+				Page:    firstNonZero(page, params.Arguments.Page),
+			},
+		}
+		return client.Pipelines.ListProjectPipelines(params.Arguments.Project, opts, gitlab.WithContext(ctx))
+	})
+	if err != nil {
+		return apiErrorResult(err)
+	}
+
+	return jsonResult(forgeclient.ListResult{Items: pipelines, Pagination: meta})
+}
+
+func main() {
+	// Initialize configuration from environment
+	gitlabURL = os.Getenv("GITLAB_URL")
+	if gitlabURL == "" {
+		gitlabURL = defaultGitLabURL
+	}
+	defaultToken = os.Getenv("GITLAB_TOKEN")
+
+	rateLimitMaxWait = defaultRateLimitMaxWait
+	if v := os.Getenv("GITLAB_RATE_LIMIT_MAX_WAIT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			rateLimitMaxWait = d
+		} else {
+			log.Printf("Ignoring invalid GITLAB_RATE_LIMIT_MAX_WAIT %q: %v", v, err)
+		}
+	}
+
+	// Log configuration (without sensitive data)
+	log.Printf("Starting MCP GitLab Proxy Server...")
+	log.Printf("GitLab URL: %s", gitlabURL)
+	log.Printf("Rate limit max wait: %s", rateLimitMaxWait)
+	if defaultToken != "" {
+		log.Printf("Default GitLab token configured")
+	}
+
+	// Create server
+	server := mcp.NewServer("mcp-gitlab-proxy", "v1.0.0", nil)
+
+	// Add tools
+	server.AddTools(
+		mcp.NewServerTool("gitlab_api", "Make a generic GitLab API call", CallGitLabAPI,
+			mcp.Input(
+				mcp.Property("endpoint", mcp.Description("API endpoint (e.g., /users or full URL)")),
+				mcp.Property("method", mcp.Description("HTTP method (GET, POST, PUT, DELETE, PATCH)")),
+				mcp.Property("token", mcp.Description("GitLab personal access token (optional)")),
+				mcp.Property("body", mcp.Description("Request body for POST/PUT/PATCH requests")),
+				mcp.Property("headers", mcp.Description("Additional headers to include")),
+				mcp.Property("all_pages", mcp.Description("Follow X-Next-Page and fetch every page (endpoint must return a JSON array)")),
+				mcp.Property("max_results", mcp.Description("Stop once this many items have been collected (implies all_pages)")),
+			),
+		),
+		mcp.NewServerTool("search_projects", "Search GitLab projects", SearchProjects,
+			mcp.Input(
+				mcp.Property("query", mcp.Description("Search query")),
+				mcp.Property("per_page", mcp.Description("Results per page (max 100)")),
+				mcp.Property("page", mcp.Description("Page number")),
+				mcp.Property("all_pages", mcp.Description("Fetch every page of results")),
+				mcp.Property("max_results", mcp.Description("Stop once this many results have been collected (implies all_pages)")),
+			),
+		),
+		mcp.NewServerTool("get_user", "Get GitLab user information", GetUser,
+			mcp.Input(
+				mcp.Property("username", mcp.Description("GitLab username")),
+			),
+		),
+		mcp.NewServerTool("list_merge_requests", "List merge requests on a GitLab project", ListMergeRequests,
+			mcp.Input(
+				mcp.Property("project", mcp.Description("Project ID or URL-encoded path (e.g., group/project)")),
+				mcp.Property("state", mcp.Description("MR state: opened, closed, locked, or merged")),
+				mcp.Property("target_branch", mcp.Description("Filter by target branch")),
+				mcp.Property("source_branch", mcp.Description("Filter by source branch")),
+				mcp.Property("per_page", mcp.Description("Results per page (max 100)")),
+				mcp.Property("page", mcp.Description("Page number")),
+				mcp.Property("all_pages", mcp.Description("Fetch every page of results")),
+				mcp.Property("max_results", mcp.Description("Stop once this many merge requests have been collected (implies all_pages)")),
+			),
+		),
+		mcp.NewServerTool("list_pipelines", "List pipelines on a GitLab project", ListPipelines,
+			mcp.Input(
+				mcp.Property("project", mcp.Description("Project ID or URL-encoded path (e.g., group/project)")),
+				mcp.Property("status", mcp.Description("Filter by status: running, pending, success, failed, canceled, skipped, etc.")),
+				mcp.Property("ref", mcp.Description("Filter by ref (branch or tag)")),
+				mcp.Property("per_page", mcp.Description("Results per page (max 100)")),
+				mcp.Property("page", mcp.Description("Page number")),
+				mcp.Property("all_pages", mcp.Description("Fetch every page of results")),
+				mcp.Property("max_results", mcp.Description("Stop once this many pipelines have been collected (implies all_pages)")),
+			),
+		),
+	)
+
+	// Add resources
+	server.AddResources(
+		&mcp.ServerResource{
+			Resource: &mcp.Resource{
+				URI:         "gitlab://api-docs",
+				Name:        "GitLab API Documentation",
+				Description: "Information about using the GitLab proxy server",
+			},
+			Handler: func(ctx context.Context, session *mcp.ServerSession, params *mcp.ReadResourceParams) (*mcp.ReadResourceResult, error) {
+				docs := fmt.Sprintf(`GitLab Proxy MCP Server
+=======================
+
+This server provides a proxy to the GitLab API, backed by go-gitlab, with
+the following tools:
+
+1. gitlab_api - Make a generic GitLab API call (escape hatch)
+   - endpoint: API endpoint path or full URL
+   - method: HTTP method (default: GET)
+   - token: GitLab personal access token (optional, uses GITLAB_TOKEN env if not provided)
+   - body: Request body for POST/PUT/PATCH
+   - headers: Additional headers
+
+2. search_projects - Search GitLab projects
+   - query: Search query (required)
+   - per_page: Results per page
+   - page: Page number
+
+3. get_user - Get GitLab user information
+   - username: GitLab username (required)
+
+4. list_merge_requests - List merge requests on a project
+   - project: Project ID or URL-encoded path (required)
+   - state, target_branch, source_branch, per_page, page, all_pages, max_results
+
+5. list_pipelines - List pipelines on a project
+   - project: Project ID or URL-encoded path (required)
+   - status, ref, per_page, page, all_pages, max_results
+
+Pagination:
+- search_projects, list_merge_requests, list_pipelines, and gitlab_api
+  accept all_pages (follow X-Next-Page across every page) and max_results
+  (stop once that many items have been collected). Results are returned
+  alongside a "pagination" object (page, next_page, prev_page, last_page,
+  total_count) describing the page(s) that were fetched.
+
+Configuration:
+- GitLab URL: %s
+- Default Token: %s
+
+Environment Variables:
+- GITLAB_TOKEN: Default GitLab personal access token
+- GITLAB_URL: Custom GitLab instance URL (for self-hosted GitLab)
+- GITLAB_RATE_LIMIT_MAX_WAIT: Longest a call will sleep for a rate limit to
+  reset before failing fast with a RATE_LIMITED error (default: 2m)
+
+Rate Limiting:
+- All tools share a rate limiter: once a response reports the budget is
+  exhausted, subsequent calls sleep until reset (bounded by
+  GITLAB_RATE_LIMIT_MAX_WAIT) rather than failing immediately`, gitlabURL, func() string {
+					if defaultToken != "" {
+						return "Configured"
+					}
+					return "Not configured"
+				}())
+
+				return &mcp.ReadResourceResult{
+					Contents: []*mcp.ResourceContents{
+						{
+							URI:      "gitlab://api-docs",
+							MIMEType: "text/plain",
+							Text:     docs,
+						},
+					},
+				}, nil
+			},
+		},
+	)
+
+	// Run server
+	if err := server.Run(context.Background(), mcp.NewStdioTransport()); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/shuymn/mcp/internal/forgeclient"
+)
+
+// pageInfo converts a go-gitlab response's X-Next-Page/X-Total-Pages
+// headers (go-gitlab already parses these for us on every request) into a
+// forge-agnostic forgeclient.PageInfo.
+func pageInfo(resp *gitlab.Response) forgeclient.PageInfo {
+	if resp == nil {
+		return forgeclient.PageInfo{}
+	}
+	return forgeclient.PageInfo{
+		Page:       resp.CurrentPage,
+		NextPage:   resp.NextPage,
+		PrevPage:   resp.PreviousPage,
+		LastPage:   resp.TotalPages,
+		TotalCount: resp.TotalItems,
+	}
+}
+
+// paginate drives fetch across successive pages, translating go-gitlab's
+// *gitlab.Response into forgeclient.PageInfo so the page-walking logic can
+// live in one place shared with the GitHub proxy.
+func paginate[T any](allPages bool, maxResults int, fetch func(page int) ([]T, *gitlab.Response, error)) ([]T, forgeclient.PageInfo, error) {
+	return forgeclient.Paginate(allPages, maxResults, func(page int) ([]T, forgeclient.PageInfo, error) {
+		items, resp, err := fetch(page)
+		if err != nil {
+			return nil, forgeclient.PageInfo{}, err
+		}
+		return items, pageInfo(resp), nil
+	})
+}
+
+// firstNonZero returns the first of a, b that is non-zero, or zero if both are.
+func firstNonZero(a, b int) int {
+	return forgeclient.FirstNonZero(a, b)
+}
+
+// withPage returns endpoint with its "page" query parameter set, preserving
+// any other query parameters already present.
+func withPage(endpoint string, page int) (string, error) {
+	return forgeclient.WithPage(endpoint, page)
+}
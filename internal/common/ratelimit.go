@@ -0,0 +1,179 @@
+// Copyright 2025 Shu YAMANI. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimitInfo is a snapshot of the rate-limit state reported by the most
+// recent response that carried rate-limit headers.
+type RateLimitInfo struct {
+	Limit     int
+	Remaining int
+	ResetAt   time.Time
+}
+
+type progressNotifierKey struct{}
+
+// WithProgressNotifier attaches notify to ctx. RateLimitedTransport calls it
+// with a human-readable message whenever it sleeps waiting for a rate limit
+// to reset, so long calls do not appear hung to the caller.
+func WithProgressNotifier(ctx context.Context, notify func(message string)) context.Context {
+	return context.WithValue(ctx, progressNotifierKey{}, notify)
+}
+
+func progressNotifierFromContext(ctx context.Context) func(string) {
+	notify, _ := ctx.Value(progressNotifierKey{}).(func(string))
+	return notify
+}
+
+// RateLimitedTransport wraps an http.RoundTripper, tracking a forge API's
+// X-RateLimit-* and Retry-After headers so every request sharing it waits
+// out a throttle instead of hammering an exhausted budget. A request that
+// would have to wait longer than MaxWait fails fast with an
+// ErrCodeRateLimited MCPError instead of blocking indefinitely.
+type RateLimitedTransport struct {
+	Base    http.RoundTripper
+	MaxWait time.Duration
+
+	mu   sync.Mutex
+	info RateLimitInfo
+}
+
+func (t *RateLimitedTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if wait, resetAt, ok := t.waitNeeded(); ok {
+		if err := t.wait(req.Context(), wait, resetAt); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := t.base().RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	t.recordLimits(resp)
+
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return resp, nil
+	}
+
+	retryAfter, ok := retryAfterDuration(resp)
+	if !ok {
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	if retryAfter > t.MaxWait {
+		return nil, NewMCPError(ErrCodeRateLimited, "rate limited by upstream API", map[string]any{
+			"reset_at": time.Now().Add(retryAfter),
+		})
+	}
+	if err := t.wait(req.Context(), retryAfter, time.Now().Add(retryAfter)); err != nil {
+		return nil, err
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = body
+	}
+	return t.RoundTrip(req)
+}
+
+func (t *RateLimitedTransport) waitNeeded() (time.Duration, time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.info.Remaining > 0 || t.info.ResetAt.IsZero() {
+		return 0, time.Time{}, false
+	}
+	wait := time.Until(t.info.ResetAt)
+	if wait <= 0 {
+		return 0, time.Time{}, false
+	}
+	return wait, t.info.ResetAt, true
+}
+
+func (t *RateLimitedTransport) wait(ctx context.Context, wait time.Duration, resetAt time.Time) error {
+	if wait > t.MaxWait {
+		return NewMCPError(ErrCodeRateLimited, "rate limit wait exceeds configured MaxWait", map[string]any{
+			"wait":     wait.String(),
+			"max_wait": t.MaxWait.String(),
+			"reset_at": resetAt,
+		})
+	}
+
+	if notify := progressNotifierFromContext(ctx); notify != nil {
+		notify(fmt.Sprintf("rate limited, waiting %s until %s", wait.Round(time.Second), resetAt.Format(time.RFC3339)))
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (t *RateLimitedTransport) recordLimits(resp *http.Response) {
+	remaining, hasRemaining := intHeader(resp.Header, "X-RateLimit-Remaining")
+	limit, hasLimit := intHeader(resp.Header, "X-RateLimit-Limit")
+	resetUnix, hasReset := intHeader(resp.Header, "X-RateLimit-Reset")
+	if !hasRemaining && !hasReset {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if hasRemaining {
+		t.info.Remaining = remaining
+	}
+	if hasLimit {
+		t.info.Limit = limit
+	}
+	if hasReset {
+		t.info.ResetAt = time.Unix(int64(resetUnix), 0)
+	}
+}
+
+func intHeader(h http.Header, key string) (int, bool) {
+	v := h.Get(key)
+	if v == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+func retryAfterDuration(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	secs, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(secs) * time.Second, true
+}
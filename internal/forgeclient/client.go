@@ -0,0 +1,59 @@
+// Copyright 2025 Shu YAMANI. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package forgeclient holds the HTTP transport and pagination pieces shared
+// by the forge proxies (cmd/mcp-github-proxy, cmd/mcp-gitlab-proxy): a
+// rate-limit-aware transport, static-token auth, and a forge-agnostic page
+// walker. Each proxy still builds its own typed client (go-github,
+// go-gitlab) on top of the *http.Client returned here.
+package forgeclient
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/shuymn/mcp/internal/common"
+)
+
+// NewHTTPClient builds an *http.Client wrapping a common.RateLimitedTransport
+// so every tool sharing it waits out a throttle instead of hammering an
+// exhausted budget. When token is non-empty it is sent as an OAuth2 bearer
+// token via the standard oauth2.Transport; otherwise requests are sent
+// unauthenticated.
+func NewHTTPClient(token string, maxWait time.Duration) *http.Client {
+	transport := sharedTransport(maxWait)
+	if token == "" {
+		return &http.Client{Transport: transport}
+	}
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+	return &http.Client{Transport: &oauth2.Transport{Base: transport, Source: ts}}
+}
+
+// NewHTTPClientWithSource is like NewHTTPClient but authenticates with an
+// arbitrary oauth2.TokenSource (e.g. one that mints short-lived tokens)
+// instead of a single static token.
+func NewHTTPClientWithSource(source oauth2.TokenSource, maxWait time.Duration) *http.Client {
+	transport := sharedTransport(maxWait)
+	return &http.Client{Transport: &oauth2.Transport{Base: transport, Source: source}}
+}
+
+// NewRateLimitedHTTPClient builds an *http.Client wrapping a
+// common.RateLimitedTransport with no auth transport layered on top, for
+// client libraries (like go-gitlab) that apply their own auth headers on
+// top of whatever *http.Client they're given.
+func NewRateLimitedHTTPClient(maxWait time.Duration) *http.Client {
+	return &http.Client{Transport: sharedTransport(maxWait)}
+}
+
+// sharedTransports holds one RateLimitedTransport per maxWait, so every
+// client built in this process with the same maxWait shares rate-limit state.
+var sharedTransports sync.Map // time.Duration -> *common.RateLimitedTransport
+
+func sharedTransport(maxWait time.Duration) *common.RateLimitedTransport {
+	t, _ := sharedTransports.LoadOrStore(maxWait, &common.RateLimitedTransport{MaxWait: maxWait})
+	return t.(*common.RateLimitedTransport)
+}
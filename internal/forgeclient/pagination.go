@@ -0,0 +1,83 @@
+// Copyright 2025 Shu YAMANI. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package forgeclient
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// PageInfo is a forge-agnostic view of where a list result sits in
+// pagination. Each proxy derives it from whatever its client library
+// already parsed for the page it fetched: go-github's Link header, or
+// go-gitlab's X-Next-Page/X-Total-Pages headers.
+type PageInfo struct {
+	Page       int `json:"page,omitempty"`
+	NextPage   int `json:"next_page,omitempty"`
+	PrevPage   int `json:"prev_page,omitempty"`
+	FirstPage  int `json:"first_page,omitempty"`
+	LastPage   int `json:"last_page,omitempty"`
+	TotalCount int `json:"total_count,omitempty"`
+}
+
+// ListResult wraps a typed list tool's items together with pagination
+// metadata for the page(s) that were fetched.
+type ListResult struct {
+	Items      any      `json:"items"`
+	Pagination PageInfo `json:"pagination"`
+}
+
+// Paginate drives fetch across successive pages. fetch is called with the
+// page to request next (0 meaning "first page"). It stops once maxResults
+// items have been collected, once allPages is false and maxResults is 0
+// (so only the first page was ever wanted), or once the response reports
+// no further page. maxResults > 0 implies allPages, since the only way to
+// collect more than one page's worth of items is to keep paging.
+func Paginate[T any](allPages bool, maxResults int, fetch func(page int) ([]T, PageInfo, error)) ([]T, PageInfo, error) {
+	var all []T
+	var info PageInfo
+	page := 0
+
+	for {
+		items, pi, err := fetch(page)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		all = append(all, items...)
+		info = pi
+
+		if maxResults > 0 && len(all) >= maxResults {
+			all = all[:maxResults]
+			break
+		}
+		if (!allPages && maxResults == 0) || pi.NextPage == 0 {
+			break
+		}
+		page = pi.NextPage
+	}
+
+	return all, info, nil
+}
+
+// FirstNonZero returns the first of a, b that is non-zero, or zero if both are.
+func FirstNonZero(a, b int) int {
+	if a != 0 {
+		return a
+	}
+	return b
+}
+
+// WithPage returns endpoint with its "page" query parameter set, preserving
+// any other query parameters already present.
+func WithPage(endpoint string, page int) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("parsing endpoint: %w", err)
+	}
+	q := u.Query()
+	q.Set("page", fmt.Sprintf("%d", page))
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 Shu YAMANI. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package forgeclient
+
+import "testing"
+
+func TestPaginateMaxResultsImpliesAllPages(t *testing.T) {
+	// Three pages of 10 items each; max_results is set but all_pages is
+	// not, which every tool's doc string claims still pages until
+	// max_results is satisfied.
+	pages := [][]int{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		{10, 11, 12, 13, 14, 15, 16, 17, 18, 19},
+		{20, 21, 22, 23, 24, 25, 26, 27, 28, 29},
+	}
+	calls := 0
+	fetch := func(page int) ([]int, PageInfo, error) {
+		calls++
+		info := PageInfo{}
+		if page+1 < len(pages) {
+			info.NextPage = page + 1
+		}
+		return pages[page], info, nil
+	}
+
+	items, _, err := Paginate(false, 25, fetch)
+	if err != nil {
+		t.Fatalf("Paginate: %v", err)
+	}
+	if len(items) != 25 {
+		t.Fatalf("got %d items, want 25 (max_results should keep paging past the first page)", len(items))
+	}
+	if calls != 3 {
+		t.Fatalf("fetch called %d times, want 3", calls)
+	}
+}
+
+func TestPaginateSinglePageWithoutAllPagesOrMaxResults(t *testing.T) {
+	calls := 0
+	fetch := func(page int) ([]int, PageInfo, error) {
+		calls++
+		return []int{0, 1, 2}, PageInfo{NextPage: page + 1}, nil
+	}
+
+	items, _, err := Paginate(false, 0, fetch)
+	if err != nil {
+		t.Fatalf("Paginate: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("got %d items, want 3 (first page only)", len(items))
+	}
+	if calls != 1 {
+		t.Fatalf("fetch called %d times, want 1", calls)
+	}
+}
+
+func TestPaginateAllPagesFetchesEveryPage(t *testing.T) {
+	pages := [][]int{{0, 1}, {2, 3}}
+	fetch := func(page int) ([]int, PageInfo, error) {
+		info := PageInfo{}
+		if page+1 < len(pages) {
+			info.NextPage = page + 1
+		}
+		return pages[page], info, nil
+	}
+
+	items, _, err := Paginate(true, 0, fetch)
+	if err != nil {
+		t.Fatalf("Paginate: %v", err)
+	}
+	if len(items) != 4 {
+		t.Fatalf("got %d items, want 4 (all_pages should fetch every page)", len(items))
+	}
+}